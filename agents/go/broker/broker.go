@@ -0,0 +1,65 @@
+// Package broker defines a transport-agnostic publish/subscribe interface,
+// modeled on go-micro's broker package, so Agent can run against a live
+// websocket connection in production and an in-process implementation in
+// tests without changing any application code.
+package broker
+
+import "time"
+
+// Message is a transport-agnostic unit of data published to a topic.
+type Message struct {
+	ID      string
+	Topic   string
+	Headers map[string]string
+	Body    []byte
+	Time    time.Time
+}
+
+// Handler processes a single delivered Message. Returning an error signals
+// the delivery should be retried if the underlying Broker supports it.
+type Handler func(Message) error
+
+// Subscriber represents an active subscription created by Subscribe.
+type Subscriber interface {
+	Topic() string
+	Unsubscribe() error
+}
+
+// Options configures a Broker at construction time.
+type Options struct {
+	Addrs []string
+}
+
+// Option mutates Options.
+type Option func(*Options)
+
+// Addrs sets the addresses a Broker connects to.
+func Addrs(addrs ...string) Option {
+	return func(o *Options) { o.Addrs = addrs }
+}
+
+// SubscribeOptions configures a single Subscribe call.
+type SubscribeOptions struct {
+	Queue string
+}
+
+// SubscribeOption mutates SubscribeOptions.
+type SubscribeOption func(*SubscribeOptions)
+
+// Queue sets a shared queue name so only one subscriber in the queue group
+// receives each message, rather than every subscriber.
+func Queue(name string) SubscribeOption {
+	return func(o *SubscribeOptions) { o.Queue = name }
+}
+
+// Broker is a pluggable publish/subscribe transport. Agent depends only on
+// this interface, so the websocket transport (wsbroker) can be swapped for
+// an in-process one (memory) in tests, or for another transport entirely.
+type Broker interface {
+	Init(opts ...Option) error
+	Connect() error
+	Disconnect() error
+	Publish(topic string, msg *Message) error
+	Subscribe(topic string, handler Handler, opts ...SubscribeOption) (Subscriber, error)
+	String() string
+}
@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/hmadisonturner/IntelligenceCommunity/agents/go/broker"
+)
+
+// BenchmarkPub measures fan-out publish throughput to a single subscriber.
+func BenchmarkPub(b *testing.B) {
+	br := New()
+	br.Subscribe("bench", func(broker.Message) error { return nil })
+
+	msg := &broker.Message{Topic: "bench", Body: []byte("benchmark")}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		br.Publish("bench", msg)
+	}
+}
+
+// BenchmarkSub measures subscribe/unsubscribe churn.
+func BenchmarkSub(b *testing.B) {
+	br := New()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sub, _ := br.Subscribe("bench", func(broker.Message) error { return nil })
+		sub.Unsubscribe()
+	}
+}
@@ -0,0 +1,109 @@
+// Package memory implements broker.Broker entirely in-process, so tests and
+// local tools can exercise Agent without a live server.
+package memory
+
+import (
+	"sync"
+
+	"github.com/hmadisonturner/IntelligenceCommunity/agents/go/broker"
+)
+
+// Broker fans out each Publish to every Subscriber on the topic via a
+// goroutine-safe map. Subscribers sharing a broker.Queue name form a queue
+// group: only one member of the group receives each message, chosen by
+// round robin, instead of every subscriber getting a copy. There is no
+// network and no persistence.
+type Broker struct {
+	mu   sync.RWMutex
+	subs map[string][]*subscriber
+	seq  map[string]uint64
+}
+
+// New creates an in-memory Broker.
+func New(opts ...broker.Option) *Broker {
+	b := &Broker{subs: make(map[string][]*subscriber), seq: make(map[string]uint64)}
+	b.Init(opts...)
+	return b
+}
+
+// Init is a no-op; the memory broker takes no options.
+func (b *Broker) Init(opts ...broker.Option) error { return nil }
+
+// Connect is a no-op; there is nothing to dial.
+func (b *Broker) Connect() error { return nil }
+
+// Disconnect is a no-op; there is nothing to tear down.
+func (b *Broker) Disconnect() error { return nil }
+
+// String identifies this Broker implementation.
+func (b *Broker) String() string { return "memory" }
+
+// Publish delivers msg to every current subscriber of topic concurrently,
+// except that subscribers sharing a queue group deliver to only one member
+// of that group per message.
+func (b *Broker) Publish(topic string, msg *broker.Message) error {
+	b.mu.Lock()
+	groups := make(map[string][]*subscriber)
+	var recipients []*subscriber
+	for _, s := range b.subs[topic] {
+		if s.queue == "" {
+			recipients = append(recipients, s)
+			continue
+		}
+		groups[s.queue] = append(groups[s.queue], s)
+	}
+	for queue, members := range groups {
+		key := topic + "\x00" + queue
+		idx := b.seq[key] % uint64(len(members))
+		b.seq[key]++
+		recipients = append(recipients, members[idx])
+	}
+	b.mu.Unlock()
+
+	for _, s := range recipients {
+		go s.handler(*msg)
+	}
+	return nil
+}
+
+// Subscribe registers handler to be called for every message published to
+// topic until the returned Subscriber is unsubscribed. Passing Queue groups
+// handler with every other subscriber sharing that queue name, so only one
+// of them receives any given message.
+func (b *Broker) Subscribe(topic string, handler broker.Handler, opts ...broker.SubscribeOption) (broker.Subscriber, error) {
+	var options broker.SubscribeOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	s := &subscriber{broker: b, topic: topic, queue: options.Queue, handler: handler}
+
+	b.mu.Lock()
+	b.subs[topic] = append(b.subs[topic], s)
+	b.mu.Unlock()
+
+	return s, nil
+}
+
+type subscriber struct {
+	broker  *Broker
+	topic   string
+	queue   string
+	handler broker.Handler
+}
+
+func (s *subscriber) Topic() string { return s.topic }
+
+func (s *subscriber) Unsubscribe() error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	subs := s.broker.subs[s.topic]
+	for i, sub := range subs {
+		if sub == s {
+			s.broker.subs[s.topic] = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hmadisonturner/IntelligenceCommunity/agents/go/broker"
+)
+
+func TestPublishFansOutToAllSubscribers(t *testing.T) {
+	b := New()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	handler := func(broker.Message) error {
+		wg.Done()
+		return nil
+	}
+
+	if _, err := b.Subscribe("reports", handler); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if _, err := b.Subscribe("reports", handler); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	if err := b.Publish("reports", &broker.Message{Topic: "reports", Body: []byte("hi")}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	waitOrTimeout(t, &wg, time.Second)
+}
+
+func TestUnsubscribeStopsDelivery(t *testing.T) {
+	b := New()
+
+	delivered := make(chan struct{}, 1)
+	sub, err := b.Subscribe("topic", func(broker.Message) error {
+		delivered <- struct{}{}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	if err := sub.Unsubscribe(); err != nil {
+		t.Fatalf("unsubscribe: %v", err)
+	}
+
+	if err := b.Publish("topic", &broker.Message{Topic: "topic"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	select {
+	case <-delivered:
+		t.Fatal("message delivered after unsubscribe")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestQueueGroupSplitsDeliveryAcrossMembers(t *testing.T) {
+	b := New()
+
+	received := make(chan int, 4)
+	handlerFor := func(id int) broker.Handler {
+		return func(broker.Message) error {
+			received <- id
+			return nil
+		}
+	}
+
+	if _, err := b.Subscribe("work", handlerFor(1), broker.Queue("workers")); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if _, err := b.Subscribe("work", handlerFor(2), broker.Queue("workers")); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	const messages = 4
+	for i := 0; i < messages; i++ {
+		if err := b.Publish("work", &broker.Message{Topic: "work"}); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+
+	counts := map[int]int{}
+	for i := 0; i < messages; i++ {
+		select {
+		case id := <-received:
+			counts[id]++
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for delivery %d/%d", i+1, messages)
+		}
+	}
+
+	if counts[1] == 0 || counts[2] == 0 {
+		t.Fatalf("expected every queue member to receive at least one message, got %v", counts)
+	}
+	if counts[1] != messages/2 || counts[2] != messages/2 {
+		t.Fatalf("expected round robin to split %d messages evenly, got %v", messages, counts)
+	}
+}
+
+func waitOrTimeout(t *testing.T, wg *sync.WaitGroup, d time.Duration) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(d):
+		t.Fatal("timed out waiting for deliveries")
+	}
+}
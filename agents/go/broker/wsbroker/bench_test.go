@@ -0,0 +1,109 @@
+package wsbroker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/hmadisonturner/IntelligenceCommunity/agents/go/broker"
+	"github.com/hmadisonturner/IntelligenceCommunity/agents/go/client"
+)
+
+// benchBroker is a minimal stand-in for the real broker server, just enough
+// to ack PUBLISH/SUBSCRIBE/UNSUBSCRIBE frames over a real loopback
+// websocket connection so these benchmarks measure the same
+// serialize-send-correlate path production traffic takes, unlike
+// memory.Broker's in-process benchmarks.
+func benchBroker(b *testing.B) string {
+	b.Helper()
+
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		sendBenchFrame(conn, client.Frame{Type: client.FrameIdentity, Payload: []byte("bench")})
+		sendBenchFrame(conn, client.Frame{Type: client.FrameChannels})
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var f client.Frame
+			if err := json.Unmarshal(data, &f); err != nil {
+				return
+			}
+			switch f.Type {
+			case client.FrameSubscribe:
+				sendBenchFrame(conn, client.Frame{Type: client.FrameSubAck, RequestID: f.RequestID})
+			case client.FrameUnsubscribe:
+				sendBenchFrame(conn, client.Frame{Type: client.FrameUnsubAck, RequestID: f.RequestID})
+			case client.FramePublish:
+				sendBenchFrame(conn, client.Frame{Type: client.FramePublish, RequestID: f.RequestID})
+			}
+		}
+	}))
+	b.Cleanup(srv.Close)
+
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func sendBenchFrame(conn *websocket.Conn, f client.Frame) {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return
+	}
+	conn.WriteMessage(websocket.BinaryMessage, data)
+}
+
+// BenchmarkPublish measures publish request/reply throughput over a real
+// websocket connection, for comparison against memory.BenchmarkPub's
+// in-process fan-out.
+func BenchmarkPublish(b *testing.B) {
+	br := New(broker.Addrs(benchBroker(b)))
+	if err := br.Connect(); err != nil {
+		b.Fatalf("connect: %v", err)
+	}
+	defer br.Disconnect()
+
+	msg := &broker.Message{Topic: "bench", Body: []byte("benchmark")}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := br.Publish("bench", msg); err != nil {
+			b.Fatalf("publish: %v", err)
+		}
+	}
+}
+
+// BenchmarkSubscribeUnsubscribe measures subscribe/unsubscribe churn over a
+// real websocket connection, for comparison against memory.BenchmarkSub's
+// in-process churn.
+func BenchmarkSubscribeUnsubscribe(b *testing.B) {
+	br := New(broker.Addrs(benchBroker(b)))
+	if err := br.Connect(); err != nil {
+		b.Fatalf("connect: %v", err)
+	}
+	defer br.Disconnect()
+
+	handler := func(broker.Message) error { return nil }
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sub, err := br.Subscribe("bench", handler)
+		if err != nil {
+			b.Fatalf("subscribe: %v", err)
+		}
+		if err := sub.Unsubscribe(); err != nil {
+			b.Fatalf("unsubscribe: %v", err)
+		}
+	}
+}
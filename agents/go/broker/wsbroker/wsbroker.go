@@ -0,0 +1,138 @@
+// Package wsbroker adapts the client package's framed websocket protocol to
+// the broker.Broker interface, so Agent can be built against that interface
+// and swapped onto another transport (memory for tests, something else in
+// the future) without code changes.
+package wsbroker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hmadisonturner/IntelligenceCommunity/agents/go/broker"
+	"github.com/hmadisonturner/IntelligenceCommunity/agents/go/client"
+)
+
+// Broker adapts a client.Client to broker.Broker.
+type Broker struct {
+	opts broker.Options
+
+	mu     sync.Mutex
+	client *client.Client
+}
+
+// New creates a websocket Broker. Connect dials the first address passed via
+// broker.Addrs.
+func New(opts ...broker.Option) *Broker {
+	b := &Broker{}
+	b.Init(opts...)
+	return b
+}
+
+// Init applies the given options, replacing any previously set.
+func (b *Broker) Init(opts ...broker.Option) error {
+	for _, o := range opts {
+		o(&b.opts)
+	}
+	return nil
+}
+
+// Connect dials the first configured address.
+func (b *Broker) Connect() error {
+	if len(b.opts.Addrs) == 0 {
+		return fmt.Errorf("wsbroker: no broker address configured")
+	}
+
+	c := client.New(b.opts.Addrs[0])
+	if err := c.Connect(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.client = c
+	b.mu.Unlock()
+	return nil
+}
+
+// Disconnect closes the underlying client connection.
+func (b *Broker) Disconnect() error {
+	b.mu.Lock()
+	c := b.client
+	b.mu.Unlock()
+
+	if c == nil {
+		return nil
+	}
+	return c.Close()
+}
+
+// String identifies this Broker implementation.
+func (b *Broker) String() string { return "wsbroker" }
+
+// Publish sends msg's body and headers to topic.
+func (b *Broker) Publish(topic string, msg *broker.Message) error {
+	return b.client.Publish(topic, msg.Body, msg.Headers)
+}
+
+// Subscribe joins topic and runs handler for every delivered message,
+// acking on success and nacking (triggering redelivery) on error. Passing
+// broker.Queue groups the consumer into a Shared subscription named after
+// the queue, so only one subscriber sharing that name receives each
+// message instead of every subscriber.
+func (b *Broker) Subscribe(topic string, handler broker.Handler, opts ...broker.SubscribeOption) (broker.Subscriber, error) {
+	var options broker.SubscribeOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	copts := client.DefaultSubscribeOptions()
+	if options.Queue != "" {
+		copts.SubscriptionType = client.Shared
+		copts.SubscriptionName = options.Queue
+	}
+
+	consumer, err := b.client.Subscribe(topic, copts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := &subscriber{topic: topic, consumer: consumer, cancel: cancel}
+	go sub.run(ctx, handler)
+	return sub, nil
+}
+
+type subscriber struct {
+	topic    string
+	consumer *client.Consumer
+	cancel   context.CancelFunc
+}
+
+func (s *subscriber) Topic() string { return s.topic }
+
+func (s *subscriber) run(ctx context.Context, handler broker.Handler) {
+	for {
+		msg, err := s.consumer.Receive(ctx)
+		if err != nil {
+			return
+		}
+
+		bmsg := broker.Message{
+			ID:    msg.ID,
+			Topic: msg.Channel,
+			Body:  msg.Payload,
+			Time:  msg.PublishTime,
+		}
+
+		if err := handler(bmsg); err != nil {
+			msg.Nack()
+			continue
+		}
+		msg.Ack()
+	}
+}
+
+func (s *subscriber) Unsubscribe() error {
+	s.cancel()
+	return s.consumer.Unsubscribe()
+}
@@ -0,0 +1,497 @@
+// Package client implements a typed, versioned protocol client for the
+// IntelligenceCommunity messaging broker. It replaces the original
+// colon-delimited line protocol with framed JSON messages exchanged over a
+// websocket, and adds request/response correlation, ping/pong keepalive, and
+// automatic reconnect with exponential backoff.
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// MessageHandler is invoked for every MESSAGE frame delivered on the channel
+// it was registered for.
+type MessageHandler func(Frame)
+
+// Options configures keepalive and reconnect behavior for a Client.
+type Options struct {
+	// PingInterval is how often PING frames are sent to keep the connection
+	// alive. Zero disables keepalive pings.
+	PingInterval time.Duration
+	// InitialReconnectBackoff is the delay before the first reconnect
+	// attempt after an unexpected disconnect.
+	InitialReconnectBackoff time.Duration
+	// MaxReconnectBackoff caps the exponential backoff between reconnect
+	// attempts.
+	MaxReconnectBackoff time.Duration
+	// RequestTimeout bounds how long a request (Publish, Subscribe,
+	// Unsubscribe) waits for its reply. It also bounds how long a request
+	// in flight when the connection drops can block before the dropped
+	// connection's readLoop fails it out; a request is never left waiting
+	// past a disconnect. Zero disables the timeout.
+	RequestTimeout time.Duration
+}
+
+// DefaultOptions returns the Options used when a Client is constructed
+// without explicit overrides.
+func DefaultOptions() Options {
+	return Options{
+		PingInterval:            30 * time.Second,
+		InitialReconnectBackoff: 500 * time.Millisecond,
+		MaxReconnectBackoff:     30 * time.Second,
+		RequestTimeout:          30 * time.Second,
+	}
+}
+
+// Client speaks the versioned Frame protocol over a websocket connection. It
+// correlates requests and responses by RequestID so concurrent callers don't
+// see each other's replies interleaved, and transparently reconnects with
+// exponential backoff when the connection drops.
+type Client struct {
+	URL  string
+	Opts Options
+
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	identity string
+	channels []string
+	closing  bool
+
+	pendingMu sync.Mutex
+	pending   map[string]chan pendingReply
+
+	handlerMu     sync.Mutex
+	handlers      map[string][]registeredHandler
+	nextHandlerID uint64
+
+	subMu sync.Mutex
+	subs  map[string]SubscribeOptions
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// New creates a Client for the given broker URL using DefaultOptions.
+func New(brokerURL string) *Client {
+	return &Client{
+		URL:      brokerURL,
+		Opts:     DefaultOptions(),
+		pending:  make(map[string]chan pendingReply),
+		handlers: make(map[string][]registeredHandler),
+		subs:     make(map[string]SubscribeOptions),
+		done:     make(chan struct{}),
+	}
+}
+
+// Done returns a channel that is closed once the client has been closed,
+// useful for blocking a caller's main loop until disconnect.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Identity returns the identity assigned by the broker on connect.
+func (c *Client) Identity() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.identity
+}
+
+// Channels returns the channels advertised by the broker on connect.
+func (c *Client) Channels() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.channels
+}
+
+// Connect dials the broker, completes the identity/channels handshake, and
+// starts the background reader and keepalive goroutines.
+func (c *Client) Connect() error {
+	if _, err := url.Parse(c.URL); err != nil {
+		return fmt.Errorf("invalid URL: %v", err)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(c.URL, nil)
+	if err != nil {
+		return fmt.Errorf("dial error: %v", err)
+	}
+
+	if err := c.handshake(conn); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c.mu.Lock()
+	c.conn = conn
+	c.mu.Unlock()
+
+	go c.readLoop()
+	if c.Opts.PingInterval > 0 {
+		go c.keepalive()
+	}
+
+	log.Printf("client connected as %s", c.Identity())
+	return nil
+}
+
+// handshake reads the IDENTITY and CHANNELS frames the broker sends on
+// connect.
+func (c *Client) handshake(conn *websocket.Conn) error {
+	identity, err := readFrame(conn)
+	if err != nil {
+		return fmt.Errorf("error reading identity frame: %v", err)
+	}
+	if identity.Type != FrameIdentity {
+		return fmt.Errorf("unexpected first frame: %s", identity.Type)
+	}
+
+	channels, err := readFrame(conn)
+	if err != nil {
+		return fmt.Errorf("error reading channels frame: %v", err)
+	}
+	if channels.Type != FrameChannels {
+		return fmt.Errorf("unexpected second frame: %s", channels.Type)
+	}
+
+	c.mu.Lock()
+	c.identity = string(identity.Payload)
+	if len(channels.Payload) > 0 {
+		c.channels = strings.Split(string(channels.Payload), ",")
+	}
+	c.mu.Unlock()
+
+	return nil
+}
+
+// readLoop pulls frames off the connection, routes responses to any caller
+// waiting on RequestID, dispatches MESSAGE frames to registered handlers, and
+// reconnects on unexpected read errors.
+func (c *Client) readLoop() {
+	for {
+		conn := c.currentConn()
+		if conn == nil {
+			return
+		}
+
+		frame, err := readFrame(conn)
+		if err != nil {
+			c.mu.Lock()
+			closing := c.closing
+			c.mu.Unlock()
+			if closing {
+				return
+			}
+			log.Printf("read error: %v", err)
+			c.failPending(fmt.Errorf("connection lost: %v", err))
+			if !c.reconnect() {
+				return
+			}
+			continue
+		}
+
+		if frame.RequestID != "" {
+			c.pendingMu.Lock()
+			ch, ok := c.pending[frame.RequestID]
+			c.pendingMu.Unlock()
+			if ok {
+				ch <- pendingReply{frame: frame}
+				continue
+			}
+		}
+
+		if frame.Type == FrameMessage {
+			c.dispatch(frame)
+		}
+	}
+}
+
+// dispatch invokes every handler registered for frame.Channel.
+func (c *Client) dispatch(frame Frame) {
+	c.handlerMu.Lock()
+	handlers := append([]registeredHandler(nil), c.handlers[frame.Channel]...)
+	c.handlerMu.Unlock()
+
+	for _, h := range handlers {
+		h.fn(frame)
+	}
+}
+
+// keepalive periodically sends PING frames until the client is closed.
+func (c *Client) keepalive() {
+	ticker := time.NewTicker(c.Opts.PingInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.mu.Lock()
+		closing := c.closing
+		c.mu.Unlock()
+		if closing {
+			return
+		}
+		if err := c.send(Frame{Type: FramePing}); err != nil {
+			log.Printf("ping error: %v", err)
+		}
+	}
+}
+
+// trackSubscription records channel and opts so reconnect can replay the
+// SUBSCRIBE after the connection is reestablished.
+func (c *Client) trackSubscription(channel string, opts SubscribeOptions) {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+	c.subs[channel] = opts
+}
+
+// untrackSubscription stops replaying channel's SUBSCRIBE on reconnect.
+func (c *Client) untrackSubscription(channel string) {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+	delete(c.subs, channel)
+}
+
+// resubscribeAll replays a SUBSCRIBE frame for every subscription tracked
+// at the time of a reconnect, so consumers keep receiving messages across a
+// transient disconnect instead of silently going quiet. It runs in its own
+// goroutine so the readLoop it was triggered from is free to route the
+// SUB_ACK replies it's waiting on.
+func (c *Client) resubscribeAll() {
+	c.subMu.Lock()
+	subs := make(map[string]SubscribeOptions, len(c.subs))
+	for channel, opts := range c.subs {
+		subs[channel] = opts
+	}
+	c.subMu.Unlock()
+
+	for channel, opts := range subs {
+		optsJSON, err := json.Marshal(opts)
+		if err != nil {
+			log.Printf("resubscribe to %s failed: %v", channel, err)
+			continue
+		}
+		reply, err := c.request(Frame{Type: FrameSubscribe, Channel: channel, Payload: optsJSON})
+		if err != nil {
+			log.Printf("resubscribe to %s failed: %v", channel, err)
+			continue
+		}
+		if reply.Type != FrameSubAck {
+			log.Printf("resubscribe to %s rejected: %s", channel, reply.Payload)
+		}
+	}
+}
+
+// reconnect redials the broker with exponential backoff, replaying the
+// handshake. It returns false if the client has been closed in the meantime.
+func (c *Client) reconnect() bool {
+	backoff := c.Opts.InitialReconnectBackoff
+	if backoff <= 0 {
+		backoff = DefaultOptions().InitialReconnectBackoff
+	}
+
+	for {
+		c.mu.Lock()
+		closing := c.closing
+		c.mu.Unlock()
+		if closing {
+			return false
+		}
+
+		conn, _, err := websocket.DefaultDialer.Dial(c.URL, nil)
+		if err == nil {
+			if err := c.handshake(conn); err == nil {
+				c.mu.Lock()
+				c.conn = conn
+				c.mu.Unlock()
+				log.Printf("client reconnected as %s", c.Identity())
+				go c.resubscribeAll()
+				return true
+			}
+			conn.Close()
+		}
+
+		log.Printf("reconnect failed, retrying in %s: %v", backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > c.Opts.MaxReconnectBackoff {
+			backoff = c.Opts.MaxReconnectBackoff
+		}
+	}
+}
+
+func (c *Client) currentConn() *websocket.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn
+}
+
+// Publish sends a payload to a channel and waits for the broker's
+// acknowledgement.
+func (c *Client) Publish(channel string, payload []byte, headers map[string]string) error {
+	reply, err := c.request(Frame{
+		Type:    FramePublish,
+		Channel: channel,
+		Headers: headers,
+		Payload: payload,
+	})
+	if err != nil {
+		return fmt.Errorf("error publishing message: %v", err)
+	}
+	if reply.Type == FrameError {
+		return fmt.Errorf("publish rejected: %s", reply.Payload)
+	}
+	return nil
+}
+
+// registeredHandler pairs a MessageHandler with the id OnMessage assigned it,
+// so a single entry can be found and removed later without requiring
+// MessageHandler values to be comparable.
+type registeredHandler struct {
+	id uint64
+	fn MessageHandler
+}
+
+// OnMessage registers handler to be called for every MESSAGE frame delivered
+// on channel. It returns a function that removes the handler; callers that
+// never need to stop listening can discard it.
+func (c *Client) OnMessage(channel string, handler MessageHandler) func() {
+	c.handlerMu.Lock()
+	id := c.nextHandlerID
+	c.nextHandlerID++
+	c.handlers[channel] = append(c.handlers[channel], registeredHandler{id: id, fn: handler})
+	c.handlerMu.Unlock()
+
+	return func() { c.removeHandler(channel, id) }
+}
+
+// removeHandler drops the handler identified by id from channel's list.
+func (c *Client) removeHandler(channel string, id uint64) {
+	c.handlerMu.Lock()
+	defer c.handlerMu.Unlock()
+
+	hs := c.handlers[channel]
+	for i, h := range hs {
+		if h.id == id {
+			c.handlers[channel] = append(hs[:i], hs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Close sends a close frame and shuts down the connection.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	c.closing = true
+	conn := c.conn
+	c.mu.Unlock()
+
+	c.closeOnce.Do(func() { close(c.done) })
+
+	if conn == nil {
+		return nil
+	}
+	if err := conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")); err != nil {
+		log.Printf("error during close message: %v", err)
+	}
+	return conn.Close()
+}
+
+// pendingReply is what a caller blocked in request is waiting for: either
+// the matching response frame, or an error explaining why one is never
+// coming (the connection it was sent on dropped, or the request timed out).
+type pendingReply struct {
+	frame Frame
+	err   error
+}
+
+// request sends a frame stamped with a fresh RequestID and blocks until the
+// matching response frame arrives, the connection it was sent on is lost, or
+// Opts.RequestTimeout elapses — whichever happens first. A dropped
+// connection fails the request immediately rather than leaving the caller
+// blocked until the client reconnects, since the in-flight reply can never
+// arrive on the old connection.
+func (c *Client) request(f Frame) (Frame, error) {
+	f.RequestID = newRequestID()
+
+	replyCh := make(chan pendingReply, 1)
+	c.pendingMu.Lock()
+	c.pending[f.RequestID] = replyCh
+	c.pendingMu.Unlock()
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, f.RequestID)
+		c.pendingMu.Unlock()
+	}()
+
+	if err := c.send(f); err != nil {
+		return Frame{}, err
+	}
+
+	var timeoutCh <-chan time.Time
+	if c.Opts.RequestTimeout > 0 {
+		timer := time.NewTimer(c.Opts.RequestTimeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	select {
+	case reply := <-replyCh:
+		return reply.frame, reply.err
+	case <-timeoutCh:
+		return Frame{}, fmt.Errorf("request timed out waiting for reply")
+	}
+}
+
+// failPending resolves every request currently waiting on a reply with err,
+// so a dropped connection can't strand a caller in request forever.
+func (c *Client) failPending(err error) {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	for id, ch := range c.pending {
+		ch <- pendingReply{err: err}
+		delete(c.pending, id)
+	}
+}
+
+// send encodes and writes a frame on the current connection.
+func (c *Client) send(f Frame) error {
+	conn := c.currentConn()
+	if conn == nil {
+		return fmt.Errorf("client not connected")
+	}
+
+	data, err := f.encode()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return conn.WriteMessage(websocket.BinaryMessage, data)
+}
+
+// readFrame reads and decodes the next binary frame from conn.
+func readFrame(conn *websocket.Conn) (Frame, error) {
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return Frame{}, err
+	}
+	return decodeFrame(data)
+}
+
+// newRequestID generates a short, unique id for correlating a request with
+// its response.
+func newRequestID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func testClient(url string) *Client {
+	c := New(url)
+	c.Opts.PingInterval = 0
+	c.Opts.InitialReconnectBackoff = 10 * time.Millisecond
+	c.Opts.MaxReconnectBackoff = 20 * time.Millisecond
+	c.Opts.RequestTimeout = time.Second
+	return c
+}
+
+func TestConnectCompletesHandshake(t *testing.T) {
+	srv := newTestServer(t, func(t *testing.T, conn *syncConn) {
+		conn.recv()
+	})
+	defer srv.Close()
+
+	c := testClient(srv.wsURL())
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.Close()
+
+	if c.Identity() != "test-identity" {
+		t.Errorf("Identity() = %q, want %q", c.Identity(), "test-identity")
+	}
+	want := []string{"alpha", "beta"}
+	got := c.Channels()
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Channels() = %v, want %v", got, want)
+	}
+}
+
+func TestPublishWaitsForReply(t *testing.T) {
+	srv := newTestServer(t, func(t *testing.T, conn *syncConn) {
+		f, err := conn.recv()
+		if err != nil {
+			return
+		}
+		if f.Type != FramePublish || f.Channel != "alpha" || string(f.Payload) != "hi" {
+			t.Errorf("unexpected publish frame: %+v", f)
+		}
+		conn.send(Frame{Type: FramePublish, RequestID: f.RequestID})
+	})
+	defer srv.Close()
+
+	c := testClient(srv.wsURL())
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.Publish("alpha", []byte("hi"), nil); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+}
+
+func TestPublishRejectedByBroker(t *testing.T) {
+	srv := newTestServer(t, func(t *testing.T, conn *syncConn) {
+		f, err := conn.recv()
+		if err != nil {
+			return
+		}
+		conn.send(Frame{Type: FrameError, RequestID: f.RequestID, Payload: []byte("nope")})
+	})
+	defer srv.Close()
+
+	c := testClient(srv.wsURL())
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.Publish("alpha", []byte("hi"), nil); err == nil {
+		t.Fatal("expected error for rejected publish, got nil")
+	}
+}
+
+// TestRequestFailsFastOnDisconnect exercises the deadlock the review flagged:
+// a request in flight when the connection drops must be failed out
+// immediately rather than leaving the caller blocked until the client
+// reconnects (or forever, if it never does).
+func TestRequestFailsFastOnDisconnect(t *testing.T) {
+	srv := newTestServer(t, func(t *testing.T, conn *syncConn) {
+		// Read the request but never reply, then hang up.
+		conn.recv()
+		conn.Close()
+	})
+	defer srv.Close()
+
+	c := testClient(srv.wsURL())
+	c.Opts.RequestTimeout = 10 * time.Second // the disconnect, not the timeout, should end this
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Publish("alpha", []byte("hi"), nil)
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected an error once the connection dropped, got nil")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Publish did not return after the connection dropped; pending request is stranded")
+	}
+}
+
+// TestReconnectResubscribes simulates a transient disconnect and asserts the
+// broker sees a fresh SUBSCRIBE for the still-live consumer, and that
+// messages delivered on the new connection still reach it.
+func TestReconnectResubscribes(t *testing.T) {
+	firstConn := make(chan *syncConn, 1)
+	secondConn := make(chan *syncConn, 1)
+	resubscribed := make(chan struct{}, 1)
+	var handled int
+
+	srv := newTestServer(t, func(t *testing.T, conn *syncConn) {
+		handled++
+		onSecondConn := handled == 2
+		if onSecondConn {
+			secondConn <- conn
+		} else {
+			firstConn <- conn
+		}
+		for {
+			f, err := conn.recv()
+			if err != nil {
+				return
+			}
+			switch f.Type {
+			case FrameSubscribe:
+				conn.send(Frame{Type: FrameSubAck, RequestID: f.RequestID})
+				if onSecondConn {
+					resubscribed <- struct{}{}
+				}
+			case FrameUnsubscribe:
+				conn.send(Frame{Type: FrameUnsubAck, RequestID: f.RequestID})
+			}
+		}
+	})
+	defer srv.Close()
+
+	c := testClient(srv.wsURL())
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.Close()
+
+	consumer, err := c.Subscribe("alpha", DefaultSubscribeOptions())
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	conn1 := <-firstConn
+	conn1.Close() // simulate a transient disconnect
+
+	conn2 := <-secondConn
+
+	select {
+	case <-resubscribed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("broker never saw a replayed SUBSCRIBE on the reconnected connection")
+	}
+
+	conn2.send(Frame{Type: FrameMessage, RequestID: "msg-1", Channel: "alpha", Payload: []byte("after reconnect")})
+
+	msg, err := consumer.Receive(timeoutCtx(t, time.Second))
+	if err != nil {
+		t.Fatalf("Receive after reconnect: %v", err)
+	}
+	if string(msg.Payload) != "after reconnect" {
+		t.Errorf("Payload = %q, want %q", msg.Payload, "after reconnect")
+	}
+}
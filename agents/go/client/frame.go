@@ -0,0 +1,57 @@
+package client
+
+import "encoding/json"
+
+// ProtocolVersion identifies the wire format produced by this package. Bump
+// it whenever the Frame layout changes in a way older readers can't ignore.
+const ProtocolVersion = 1
+
+// FrameType identifies what a Frame carries.
+type FrameType string
+
+const (
+	FrameIdentity    FrameType = "IDENTITY"
+	FrameChannels    FrameType = "CHANNELS"
+	FrameSubscribe   FrameType = "SUBSCRIBE"
+	FrameSubAck      FrameType = "SUB_ACK"
+	FrameUnsubscribe FrameType = "UNSUBSCRIBE"
+	FrameUnsubAck    FrameType = "UNSUB_ACK"
+	FramePublish     FrameType = "PUBLISH"
+	FrameMessage     FrameType = "MESSAGE"
+	FrameError       FrameType = "ERROR"
+	FramePing        FrameType = "PING"
+	FramePong        FrameType = "PONG"
+	FrameAck         FrameType = "ACK"
+	FrameNack        FrameType = "NACK"
+	FrameRedeliver   FrameType = "REDELIVER"
+)
+
+// Frame is the unit of exchange between a Client and the broker. It replaces
+// the old colon-delimited line protocol ("PUBLISH:channel:message"), where a
+// message body containing ':' would desync the parser, with a self
+// describing, versioned envelope. RequestID lets a caller match a response
+// frame to the request that caused it, so concurrent calls on one connection
+// never see each other's replies.
+type Frame struct {
+	Version   int               `json:"version"`
+	Type      FrameType         `json:"type"`
+	RequestID string            `json:"request_id,omitempty"`
+	Channel   string            `json:"channel,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Payload   []byte            `json:"payload,omitempty"`
+}
+
+// encode serializes the frame as JSON, stamping the current ProtocolVersion.
+func (f Frame) encode() ([]byte, error) {
+	f.Version = ProtocolVersion
+	return json.Marshal(f)
+}
+
+// decodeFrame parses a JSON-encoded Frame received from the broker.
+func decodeFrame(data []byte) (Frame, error) {
+	var f Frame
+	if err := json.Unmarshal(data, &f); err != nil {
+		return Frame{}, err
+	}
+	return f, nil
+}
@@ -0,0 +1,53 @@
+package client
+
+import "testing"
+
+func TestFrameEncodeDecodeRoundTrip(t *testing.T) {
+	f := Frame{
+		Type:      FramePublish,
+		RequestID: "req-1",
+		Channel:   "alpha",
+		Headers:   map[string]string{"x": "y"},
+		Payload:   []byte("hello"),
+	}
+
+	data, err := f.encode()
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	decoded, err := decodeFrame(data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if decoded.Version != ProtocolVersion {
+		t.Errorf("Version = %d, want %d", decoded.Version, ProtocolVersion)
+	}
+	if decoded.Type != f.Type || decoded.RequestID != f.RequestID || decoded.Channel != f.Channel {
+		t.Fatalf("round trip mismatch: got %+v, want fields from %+v", decoded, f)
+	}
+	if decoded.Headers["x"] != "y" {
+		t.Errorf("Headers[x] = %q, want %q", decoded.Headers["x"], "y")
+	}
+	if string(decoded.Payload) != "hello" {
+		t.Errorf("Payload = %q, want %q", decoded.Payload, "hello")
+	}
+}
+
+func TestEncodeStampsCurrentProtocolVersion(t *testing.T) {
+	f := Frame{Type: FramePing, Version: 99}
+
+	data, err := f.encode()
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	decoded, err := decodeFrame(data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if decoded.Version != ProtocolVersion {
+		t.Errorf("encode did not overwrite Version: got %d, want %d", decoded.Version, ProtocolVersion)
+	}
+}
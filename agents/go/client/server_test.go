@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var testUpgrader = websocket.Upgrader{}
+
+// syncConn serializes writes to a websocket connection shared between the
+// goroutine running runBrokerStub-style broker logic and the test goroutine
+// itself, which sometimes pushes frames (e.g. MESSAGE) directly to drive a
+// scenario. gorilla/websocket forbids concurrent writers on one connection,
+// so every write goes through send.
+type syncConn struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+func (sc *syncConn) send(f Frame) error {
+	data, err := f.encode()
+	if err != nil {
+		return err
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.conn.WriteMessage(websocket.BinaryMessage, data)
+}
+
+func (sc *syncConn) recv() (Frame, error) {
+	_, data, err := sc.conn.ReadMessage()
+	if err != nil {
+		return Frame{}, err
+	}
+	return decodeFrame(data)
+}
+
+func (sc *syncConn) Close() error { return sc.conn.Close() }
+
+// testServer is a minimal stand-in for the broker, used to exercise Client
+// against a real websocket connection without a live deployment. Each test
+// supplies a handle func that owns the connection after the
+// IDENTITY/CHANNELS handshake testServer sends automatically.
+type testServer struct {
+	srv *httptest.Server
+}
+
+// newTestServer starts an httptest server that completes the handshake on
+// every incoming connection, then hands the connection to handle.
+func newTestServer(t *testing.T, handle func(t *testing.T, conn *syncConn)) *testServer {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		raw, err := testUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		conn := &syncConn{conn: raw}
+		defer conn.Close()
+
+		if err := conn.send(Frame{Type: FrameIdentity, Payload: []byte("test-identity")}); err != nil {
+			t.Errorf("send identity: %v", err)
+			return
+		}
+		if err := conn.send(Frame{Type: FrameChannels, Payload: []byte("alpha,beta")}); err != nil {
+			t.Errorf("send channels: %v", err)
+			return
+		}
+
+		handle(t, conn)
+	})
+
+	return &testServer{srv: httptest.NewServer(mux)}
+}
+
+// wsURL returns the server's address as a ws:// URL Client.Connect can dial.
+func (s *testServer) wsURL() string {
+	return "ws" + strings.TrimPrefix(s.srv.URL, "http")
+}
+
+func (s *testServer) Close() { s.srv.Close() }
+
+// timeoutCtx returns a context that is cancelled after d, for bounding calls
+// like Consumer.Receive in tests so a bug can't hang the test suite.
+func timeoutCtx(t *testing.T, d time.Duration) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	t.Cleanup(cancel)
+	return ctx
+}
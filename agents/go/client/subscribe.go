@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// SubscriptionType controls how messages on a channel are distributed among
+// multiple consumers sharing the same subscription.
+type SubscriptionType string
+
+const (
+	Exclusive SubscriptionType = "Exclusive"
+	Shared    SubscriptionType = "Shared"
+	Failover  SubscriptionType = "Failover"
+	KeyShared SubscriptionType = "KeyShared"
+)
+
+// InitialPosition controls where a new subscription starts reading from.
+type InitialPosition string
+
+const (
+	Latest   InitialPosition = "Latest"
+	Earliest InitialPosition = "Earliest"
+)
+
+// SubscribeOptions configures the delivery semantics of a Consumer.
+type SubscribeOptions struct {
+	SubscriptionType SubscriptionType `json:"subscription_type"`
+	// SubscriptionName groups every Consumer that shares it into a single
+	// logical subscription; with SubscriptionType Shared, the broker
+	// delivers each message to exactly one consumer in the group rather
+	// than to all of them. Leave empty for a standalone subscription.
+	SubscriptionName    string          `json:"subscription_name,omitempty"`
+	InitialPosition     InitialPosition `json:"initial_position"`
+	ReceiverQueueSize   int             `json:"receiver_queue_size"`
+	NackRedeliveryDelay time.Duration   `json:"nack_redelivery_delay"`
+}
+
+// DefaultSubscribeOptions returns the options used when a caller just wants
+// at-least-once delivery to a single consumer.
+func DefaultSubscribeOptions() SubscribeOptions {
+	return SubscribeOptions{
+		SubscriptionType:    Exclusive,
+		InitialPosition:     Latest,
+		ReceiverQueueSize:   1000,
+		NackRedeliveryDelay: time.Minute,
+	}
+}
+
+// Message is a single delivery from a channel subscription. Every Message
+// must be Acked or Nacked so the broker knows whether to redeliver it.
+type Message struct {
+	ID          string
+	Channel     string
+	Payload     []byte
+	PublishTime time.Time
+
+	consumer *Consumer
+}
+
+// Ack confirms successful processing of the message.
+func (m Message) Ack() error {
+	return m.consumer.ack(m.ID)
+}
+
+// Nack indicates the message was not processed successfully. The consumer
+// requests redelivery after its configured NackRedeliveryDelay.
+func (m Message) Nack() error {
+	return m.consumer.nack(m.ID)
+}
+
+// Consumer receives messages from a channel subscription, tracking
+// outstanding (unacked) deliveries so Nack can trigger redelivery.
+type Consumer struct {
+	channel    string
+	client     *Client
+	opts       SubscribeOptions
+	queue      chan Message
+	unregister func()
+
+	mu       sync.Mutex
+	inFlight map[string]Message
+	timers   map[string]*time.Timer
+}
+
+// Subscribe joins a channel with the given delivery options and returns a
+// Consumer to pull messages from. The options are sent to the broker as the
+// SUBSCRIBE frame's payload so it knows how to queue and redeliver.
+func (c *Client) Subscribe(channel string, opts SubscribeOptions) (*Consumer, error) {
+	if opts.ReceiverQueueSize <= 0 {
+		opts.ReceiverQueueSize = DefaultSubscribeOptions().ReceiverQueueSize
+	}
+	if opts.NackRedeliveryDelay <= 0 {
+		opts.NackRedeliveryDelay = DefaultSubscribeOptions().NackRedeliveryDelay
+	}
+
+	optsJSON, err := json.Marshal(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding subscribe options: %v", err)
+	}
+
+	reply, err := c.request(Frame{Type: FrameSubscribe, Channel: channel, Payload: optsJSON})
+	if err != nil {
+		return nil, fmt.Errorf("error subscribing to channel: %v", err)
+	}
+	if reply.Type != FrameSubAck {
+		return nil, fmt.Errorf("failed to subscribe: %s", reply.Payload)
+	}
+
+	consumer := &Consumer{
+		channel:  channel,
+		client:   c,
+		opts:     opts,
+		queue:    make(chan Message, opts.ReceiverQueueSize),
+		inFlight: make(map[string]Message),
+		timers:   make(map[string]*time.Timer),
+	}
+	consumer.unregister = c.OnMessage(channel, consumer.deliver)
+	c.trackSubscription(channel, opts)
+
+	return consumer, nil
+}
+
+// Unsubscribe tells the broker to stop delivering messages on the consumer's
+// channel, deregisters its handler so no future MESSAGE frame is queued, and
+// cancels any pending nack-redelivery timers. After Unsubscribe returns, the
+// Consumer's queue receives nothing further.
+func (co *Consumer) Unsubscribe() error {
+	co.unregister()
+	co.client.untrackSubscription(co.channel)
+
+	co.mu.Lock()
+	for id, t := range co.timers {
+		t.Stop()
+		delete(co.timers, id)
+	}
+	co.mu.Unlock()
+
+	reply, err := co.client.request(Frame{Type: FrameUnsubscribe, Channel: co.channel})
+	if err != nil {
+		return fmt.Errorf("error unsubscribing from channel: %v", err)
+	}
+	if reply.Type != FrameUnsubAck {
+		return fmt.Errorf("failed to unsubscribe: %s", reply.Payload)
+	}
+	return nil
+}
+
+// deliver queues an incoming MESSAGE frame for Receive/ReceiveAsync and
+// records it as in-flight until it is Acked or redelivered.
+func (co *Consumer) deliver(f Frame) {
+	msg := Message{
+		ID:          f.RequestID,
+		Channel:     f.Channel,
+		Payload:     f.Payload,
+		PublishTime: time.Now(),
+		consumer:    co,
+	}
+
+	co.mu.Lock()
+	co.inFlight[msg.ID] = msg
+	co.mu.Unlock()
+
+	co.queue <- msg
+}
+
+// Receive blocks until a message is available or ctx is done.
+func (co *Consumer) Receive(ctx context.Context) (Message, error) {
+	select {
+	case msg := <-co.queue:
+		return msg, nil
+	case <-ctx.Done():
+		return Message{}, ctx.Err()
+	}
+}
+
+// ReceiveAsync returns a channel of incoming messages.
+func (co *Consumer) ReceiveAsync() <-chan Message {
+	return co.queue
+}
+
+// ack sends an ACK frame for id and stops tracking it.
+func (co *Consumer) ack(id string) error {
+	co.mu.Lock()
+	delete(co.inFlight, id)
+	if t, ok := co.timers[id]; ok {
+		t.Stop()
+		delete(co.timers, id)
+	}
+	co.mu.Unlock()
+
+	return co.client.send(Frame{Type: FrameAck, Channel: co.channel, Payload: []byte(id)})
+}
+
+// nack notifies the broker the message wasn't processed, then schedules a
+// REDELIVER request after NackRedeliveryDelay if nothing acks it first.
+func (co *Consumer) nack(id string) error {
+	co.mu.Lock()
+	if _, ok := co.inFlight[id]; !ok {
+		co.mu.Unlock()
+		return fmt.Errorf("nack: unknown message %s", id)
+	}
+	if _, scheduled := co.timers[id]; !scheduled {
+		co.timers[id] = time.AfterFunc(co.opts.NackRedeliveryDelay, func() {
+			co.mu.Lock()
+			delete(co.timers, id)
+			co.mu.Unlock()
+			if err := co.client.send(Frame{Type: FrameRedeliver, Channel: co.channel, Payload: []byte(id)}); err != nil {
+				log.Printf("redeliver request for %s failed: %v", id, err)
+			}
+		})
+	}
+	co.mu.Unlock()
+
+	return co.client.send(Frame{Type: FrameNack, Channel: co.channel, Payload: []byte(id)})
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// runBrokerStub drives the server side of a Subscribe/Ack/Nack/Unsubscribe
+// exchange: it records every frame it sees onto seen and answers
+// SUBSCRIBE/UNSUBSCRIBE with the acks Client expects.
+func runBrokerStub(t *testing.T, conn *syncConn, seen chan<- Frame) {
+	for {
+		f, err := conn.recv()
+		if err != nil {
+			return
+		}
+		seen <- f
+		switch f.Type {
+		case FrameSubscribe:
+			conn.send(Frame{Type: FrameSubAck, RequestID: f.RequestID})
+		case FrameUnsubscribe:
+			conn.send(Frame{Type: FrameUnsubAck, RequestID: f.RequestID})
+		}
+	}
+}
+
+func TestSubscribeSendsOptionsAndDeliversMessages(t *testing.T) {
+	seen := make(chan Frame, 8)
+	connCh := make(chan *syncConn, 1)
+
+	srv := newTestServer(t, func(t *testing.T, conn *syncConn) {
+		connCh <- conn
+		runBrokerStub(t, conn, seen)
+	})
+	defer srv.Close()
+
+	c := testClient(srv.wsURL())
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.Close()
+
+	opts := DefaultSubscribeOptions()
+	opts.SubscriptionType = Shared
+	consumer, err := c.Subscribe("alpha", opts)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	select {
+	case f := <-seen:
+		if f.Type != FrameSubscribe || f.Channel != "alpha" {
+			t.Fatalf("unexpected subscribe frame: %+v", f)
+		}
+		var sent SubscribeOptions
+		if err := json.Unmarshal(f.Payload, &sent); err != nil {
+			t.Fatalf("decode subscribe payload: %v", err)
+		}
+		if sent.SubscriptionType != Shared {
+			t.Errorf("SubscriptionType = %q, want %q", sent.SubscriptionType, Shared)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("broker never saw the SUBSCRIBE frame")
+	}
+
+	conn := <-connCh
+	conn.send(Frame{Type: FrameMessage, RequestID: "msg-1", Channel: "alpha", Payload: []byte("hello")})
+
+	msg, err := consumer.Receive(timeoutCtx(t, time.Second))
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	if msg.ID != "msg-1" || string(msg.Payload) != "hello" {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+}
+
+func TestAckSendsAckFrame(t *testing.T) {
+	seen := make(chan Frame, 8)
+	connCh := make(chan *syncConn, 1)
+
+	srv := newTestServer(t, func(t *testing.T, conn *syncConn) {
+		connCh <- conn
+		runBrokerStub(t, conn, seen)
+	})
+	defer srv.Close()
+
+	c := testClient(srv.wsURL())
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.Close()
+
+	consumer, err := c.Subscribe("alpha", DefaultSubscribeOptions())
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	<-seen // SUBSCRIBE
+
+	conn := <-connCh
+	conn.send(Frame{Type: FrameMessage, RequestID: "msg-1", Channel: "alpha", Payload: []byte("hello")})
+
+	msg, err := consumer.Receive(timeoutCtx(t, time.Second))
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	if err := msg.Ack(); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+
+	select {
+	case f := <-seen:
+		if f.Type != FrameAck || string(f.Payload) != "msg-1" {
+			t.Fatalf("unexpected frame after Ack: %+v", f)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("broker never saw the ACK frame")
+	}
+}
+
+func TestNackSchedulesRedelivery(t *testing.T) {
+	seen := make(chan Frame, 8)
+	connCh := make(chan *syncConn, 1)
+
+	srv := newTestServer(t, func(t *testing.T, conn *syncConn) {
+		connCh <- conn
+		runBrokerStub(t, conn, seen)
+	})
+	defer srv.Close()
+
+	c := testClient(srv.wsURL())
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.Close()
+
+	opts := DefaultSubscribeOptions()
+	opts.NackRedeliveryDelay = 20 * time.Millisecond
+	consumer, err := c.Subscribe("alpha", opts)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	<-seen // SUBSCRIBE
+
+	conn := <-connCh
+	conn.send(Frame{Type: FrameMessage, RequestID: "msg-1", Channel: "alpha", Payload: []byte("hello")})
+
+	msg, err := consumer.Receive(timeoutCtx(t, time.Second))
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	if err := msg.Nack(); err != nil {
+		t.Fatalf("Nack: %v", err)
+	}
+
+	var sawNack, sawRedeliver bool
+	deadline := time.After(time.Second)
+	for !sawNack || !sawRedeliver {
+		select {
+		case f := <-seen:
+			switch f.Type {
+			case FrameNack:
+				sawNack = true
+			case FrameRedeliver:
+				sawRedeliver = true
+			}
+		case <-deadline:
+			t.Fatalf("timed out waiting for NACK/REDELIVER: nack=%v redeliver=%v", sawNack, sawRedeliver)
+		}
+	}
+}
+
+func TestUnsubscribeSendsFrameAndStopsDelivery(t *testing.T) {
+	seen := make(chan Frame, 8)
+	connCh := make(chan *syncConn, 1)
+
+	srv := newTestServer(t, func(t *testing.T, conn *syncConn) {
+		connCh <- conn
+		runBrokerStub(t, conn, seen)
+	})
+	defer srv.Close()
+
+	c := testClient(srv.wsURL())
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.Close()
+
+	consumer, err := c.Subscribe("alpha", DefaultSubscribeOptions())
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	<-seen // SUBSCRIBE
+
+	if err := consumer.Unsubscribe(); err != nil {
+		t.Fatalf("Unsubscribe: %v", err)
+	}
+
+	select {
+	case f := <-seen:
+		if f.Type != FrameUnsubscribe || f.Channel != "alpha" {
+			t.Fatalf("unexpected frame after Unsubscribe: %+v", f)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("broker never saw the UNSUBSCRIBE frame")
+	}
+
+	conn := <-connCh
+	conn.send(Frame{Type: FrameMessage, RequestID: "msg-2", Channel: "alpha", Payload: []byte("should not arrive")})
+
+	if _, err := consumer.Receive(timeoutCtx(t, 100*time.Millisecond)); err == nil {
+		t.Fatal("message delivered to consumer after Unsubscribe")
+	}
+}
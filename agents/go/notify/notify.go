@@ -0,0 +1,119 @@
+// Package notify implements a typed, multi-subscriber notification bus,
+// modeled on dcrdex's Core notification feed, so embedding code can observe
+// agent activity (connection state, subscriptions, published reports)
+// without scraping log output.
+package notify
+
+import (
+	"sync"
+	"time"
+)
+
+// Severity classifies how important a Notification is.
+type Severity uint8
+
+const (
+	Data Severity = iota
+	Info
+	Warning
+	Error
+)
+
+// String returns the human-readable name of the severity level.
+func (s Severity) String() string {
+	switch s {
+	case Data:
+		return "Data"
+	case Info:
+		return "Info"
+	case Warning:
+		return "Warning"
+	case Error:
+		return "Error"
+	default:
+		return "Unknown"
+	}
+}
+
+// Notification is a single typed event emitted by the agent.
+type Notification struct {
+	Type      string
+	Subject   string
+	Details   string
+	Severity  Severity
+	Timestamp time.Time
+	Payload   interface{}
+}
+
+// New builds a Notification stamped with the current time.
+func New(typ, subject, details string, severity Severity, payload interface{}) Notification {
+	return Notification{
+		Type:      typ,
+		Subject:   subject,
+		Details:   details,
+		Severity:  severity,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+}
+
+// Feed multiplexes Notifications to any number of subscribers, each
+// receiving its own buffered channel keyed by a subscriber id.
+type Feed struct {
+	noteMtx sync.RWMutex
+	subs    map[uint64]chan Notification
+	nextID  uint64
+}
+
+// NewFeed creates an empty Feed.
+func NewFeed() *Feed {
+	return &Feed{subs: make(map[uint64]chan Notification)}
+}
+
+// Subscribe registers a new subscriber and returns its channel along with a
+// cancel func that unregisters and closes it.
+func (f *Feed) Subscribe() (<-chan Notification, func()) {
+	f.noteMtx.Lock()
+	id := f.nextID
+	f.nextID++
+	ch := make(chan Notification, 128)
+	f.subs[id] = ch
+	f.noteMtx.Unlock()
+
+	return ch, func() { f.cancel(id) }
+}
+
+func (f *Feed) cancel(id uint64) {
+	f.noteMtx.Lock()
+	defer f.noteMtx.Unlock()
+
+	if ch, ok := f.subs[id]; ok {
+		close(ch)
+		delete(f.subs, id)
+	}
+}
+
+// Notify delivers n to every current subscriber. A subscriber whose channel
+// is full has the notification dropped rather than blocking the emitter.
+func (f *Feed) Notify(n Notification) {
+	f.noteMtx.RLock()
+	defer f.noteMtx.RUnlock()
+
+	for _, ch := range f.subs {
+		select {
+		case ch <- n:
+		default:
+		}
+	}
+}
+
+// Shutdown closes every subscriber channel and removes them from the feed.
+func (f *Feed) Shutdown() {
+	f.noteMtx.Lock()
+	defer f.noteMtx.Unlock()
+
+	for id, ch := range f.subs {
+		close(ch)
+		delete(f.subs, id)
+	}
+}
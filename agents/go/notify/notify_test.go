@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotifyDeliversToAllSubscribers(t *testing.T) {
+	f := NewFeed()
+
+	chA, _ := f.Subscribe()
+	chB, _ := f.Subscribe()
+
+	f.Notify(New("test", "subj", "details", Info, nil))
+
+	for _, ch := range []<-chan Notification{chA, chB} {
+		select {
+		case n := <-ch:
+			if n.Type != "test" || n.Severity != Info {
+				t.Fatalf("unexpected notification: %+v", n)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for notification")
+		}
+	}
+}
+
+func TestCancelStopsDelivery(t *testing.T) {
+	f := NewFeed()
+
+	ch, cancel := f.Subscribe()
+	cancel()
+
+	f.Notify(New("test", "subj", "details", Info, nil))
+
+	if _, ok := <-ch; ok {
+		t.Fatal("expected channel to be closed after cancel")
+	}
+}
@@ -0,0 +1,100 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CompetitorProfile is the static description of a competitor; the
+// generator fills in random figures within its market-share and
+// growth-rate ranges.
+type CompetitorProfile struct {
+	Name            string  `json:"name" yaml:"name"`
+	MarketShareMin  float64 `json:"market_share_min" yaml:"market_share_min"`
+	MarketShareMax  float64 `json:"market_share_max" yaml:"market_share_max"`
+	GrowthRateMin   float64 `json:"growth_rate_min" yaml:"growth_rate_min"`
+	GrowthRateMax   float64 `json:"growth_rate_max" yaml:"growth_rate_max"`
+	PriceComparison string  `json:"price_comparison" yaml:"price_comparison"`
+	Strengths       string  `json:"strengths" yaml:"strengths"`
+	Weaknesses      string  `json:"weaknesses" yaml:"weaknesses"`
+}
+
+// CategoryProfile is the static description of a product category.
+type CategoryProfile struct {
+	Name           string  `json:"name" yaml:"name"`
+	MarketSizeMin  int     `json:"market_size_min" yaml:"market_size_min"`
+	MarketSizeMax  int     `json:"market_size_max" yaml:"market_size_max"`
+	MarketShareMin float64 `json:"market_share_min" yaml:"market_share_min"`
+	MarketShareMax float64 `json:"market_share_max" yaml:"market_share_max"`
+}
+
+// Catalog describes the pool of competitors, categories, and vocabulary a
+// ReportGenerator draws from when producing a report.
+type Catalog struct {
+	Competitors     []CompetitorProfile `json:"competitors" yaml:"competitors"`
+	Categories      []CategoryProfile   `json:"categories" yaml:"categories"`
+	Trends          []string            `json:"trends" yaml:"trends"`
+	Sentiments      []string            `json:"sentiments" yaml:"sentiments"`
+	Recommendations []string            `json:"recommendations" yaml:"recommendations"`
+}
+
+// DefaultCatalog returns the catalog used when no config file is supplied;
+// it matches the figures the generator used to hard-code.
+func DefaultCatalog() Catalog {
+	return Catalog{
+		Competitors: []CompetitorProfile{
+			{Name: "MegaRetail", MarketShareMin: 15, MarketShareMax: 25, GrowthRateMin: 3, GrowthRateMax: 8, PriceComparison: "10-15% higher", Strengths: "Brand recognition, premium positioning", Weaknesses: "Higher prices, slower to adapt"},
+			{Name: "ValueMart", MarketShareMin: 20, MarketShareMax: 30, GrowthRateMin: 1, GrowthRateMax: 4, PriceComparison: "5-10% lower", Strengths: "Aggressive pricing, large scale", Weaknesses: "Lower quality, poor customer service"},
+			{Name: "TechGiants", MarketShareMin: 10, MarketShareMax: 18, GrowthRateMin: 8, GrowthRateMax: 15, PriceComparison: "Similar", Strengths: "Digital integration, logistics", Weaknesses: "Limited physical presence"},
+		},
+		Categories: []CategoryProfile{
+			{Name: "Electronics", MarketSizeMin: 1000000, MarketSizeMax: 1500000, MarketShareMin: 12, MarketShareMax: 22},
+			{Name: "Clothing", MarketSizeMin: 800000, MarketSizeMax: 1200000, MarketShareMin: 8, MarketShareMax: 18},
+			{Name: "Food", MarketSizeMin: 600000, MarketSizeMax: 900000, MarketShareMin: 5, MarketShareMax: 15},
+			{Name: "Books", MarketSizeMin: 300000, MarketSizeMax: 400000, MarketShareMin: 15, MarketShareMax: 25},
+		},
+		Trends:     []string{"Strong upward", "Moderate growth", "Stable", "Slight decline", "Volatile growth"},
+		Sentiments: []string{"Very positive", "Positive", "Neutral", "Mixed", "Concerned"},
+		Recommendations: []string{
+			"Increase marketing spend in categories with positive consumer sentiment",
+			"Develop competitive pricing strategy against ValueMart in key categories",
+			"Leverage digital channels to counter TechGiants' growing market share",
+			"Focus on quality improvements to differentiate from ValueMart",
+			"Expand product range in categories showing strong upward trends",
+			"Reduce inventory in categories with declining market trends",
+			"Invest in customer service to address our competitive weaknesses",
+			"Develop premium product lines to compete with MegaRetail",
+			"Focus on efficiency to improve margins while maintaining competitive pricing",
+			"Explore strategic partnerships to increase market share in lower-performing categories",
+		},
+	}
+}
+
+// LoadCatalog reads a Catalog from a JSON or YAML file, chosen by the
+// file's extension.
+func LoadCatalog(path string) (Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Catalog{}, fmt.Errorf("error reading catalog file: %v", err)
+	}
+
+	var catalog Catalog
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &catalog)
+	case ".json":
+		err = json.Unmarshal(data, &catalog)
+	default:
+		return Catalog{}, fmt.Errorf("unsupported catalog file extension: %s", ext)
+	}
+	if err != nil {
+		return Catalog{}, fmt.Errorf("error parsing catalog file: %v", err)
+	}
+
+	return catalog, nil
+}
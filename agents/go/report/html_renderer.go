@@ -0,0 +1,52 @@
+package report
+
+import (
+	"html/template"
+	"io"
+)
+
+const htmlReportTemplateSrc = `<!DOCTYPE html>
+<html>
+<head><title>Market Analysis Report: {{.Date}}</title></head>
+<body>
+<h1>Market Analysis Report: {{.Date}}</h1>
+<ul>
+<li>Total Market Size: ${{divMillion .MarketSize}} million</li>
+<li>Market Growth Rate: {{printf "%.1f" .MarketGrowth}}%</li>
+<li>Our Market Share: {{printf "%.1f" .OurMarketShare}}%</li>
+</ul>
+
+<h2>Competitor Analysis</h2>
+<table border="1">
+<tr><th>Competitor</th><th>Market Share</th><th>Growth Rate</th><th>Pricing</th><th>Strengths</th><th>Weaknesses</th></tr>
+{{range $name, $c := .Competitors}}<tr><td>{{$name}}</td><td>{{printf "%.1f" $c.MarketShare}}%</td><td>{{printf "%.1f" $c.GrowthRate}}%</td><td>{{$c.PriceComparison}}</td><td>{{$c.Strengths}}</td><td>{{$c.Weaknesses}}</td></tr>
+{{end}}</table>
+
+<h2>Category Performance</h2>
+<table border="1">
+<tr><th>Category</th><th>Market Size</th><th>Our Share</th><th>Trend</th><th>Sentiment</th></tr>
+{{range $name, $c := .Categories}}<tr><td>{{$name}}</td><td>${{divMillion $c.MarketSize}}M</td><td>{{printf "%.1f" $c.MarketShare}}%</td><td>{{$c.YearlyTrend}}</td><td>{{$c.ConsumerSentiment}}</td></tr>
+{{end}}</table>
+
+<h2>Strategic Recommendations</h2>
+<ol>
+{{range .Recommendations}}<li>{{.}}</li>
+{{end}}</ol>
+</body>
+</html>
+`
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"divMillion": func(n int) int { return n / 1000000 },
+}).Parse(htmlReportTemplateSrc))
+
+// HTMLRenderer renders a MarketReport as a self-contained HTML document.
+type HTMLRenderer struct{}
+
+// ContentType identifies the rendered payload as HTML.
+func (HTMLRenderer) ContentType() string { return "text/html; charset=utf-8" }
+
+// Render writes mr to w as HTML.
+func (HTMLRenderer) Render(mr MarketReport, w io.Writer) error {
+	return htmlReportTemplate.Execute(w, mr)
+}
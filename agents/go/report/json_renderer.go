@@ -0,0 +1,19 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONRenderer renders a MarketReport as indented JSON.
+type JSONRenderer struct{}
+
+// ContentType identifies the rendered payload as JSON.
+func (JSONRenderer) ContentType() string { return "application/json" }
+
+// Render writes mr to w as indented JSON.
+func (JSONRenderer) Render(mr MarketReport, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(mr)
+}
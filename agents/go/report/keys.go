@@ -0,0 +1,25 @@
+package report
+
+import "sort"
+
+// competitorNames returns mr's competitor names in sorted order, so
+// renderers produce stable output instead of Go's randomized map order.
+func competitorNames(m map[string]CompetitorData) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// categoryNames returns mr's category names in sorted order, so renderers
+// produce stable output instead of Go's randomized map order.
+func categoryNames(m map[string]CategoryAnalysis) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,46 @@
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+// MarkdownRenderer renders a MarketReport as a Markdown document with
+// tables for competitors and categories.
+type MarkdownRenderer struct{}
+
+// ContentType identifies the rendered payload as Markdown.
+func (MarkdownRenderer) ContentType() string { return "text/markdown" }
+
+// Render writes mr to w as a Markdown document.
+func (MarkdownRenderer) Render(mr MarketReport, w io.Writer) error {
+	fmt.Fprintf(w, "# Market Analysis Report: %s\n\n", mr.Date)
+	fmt.Fprintf(w, "- **Total Market Size:** $%d million\n", mr.MarketSize/1000000)
+	fmt.Fprintf(w, "- **Market Growth Rate:** %.1f%%\n", mr.MarketGrowth)
+	fmt.Fprintf(w, "- **Our Market Share:** %.1f%%\n\n", mr.OurMarketShare)
+
+	fmt.Fprintln(w, "## Competitor Analysis")
+	fmt.Fprintln(w, "| Competitor | Market Share | Growth Rate | Pricing | Strengths | Weaknesses |")
+	fmt.Fprintln(w, "|---|---|---|---|---|---|")
+	for _, name := range competitorNames(mr.Competitors) {
+		c := mr.Competitors[name]
+		fmt.Fprintf(w, "| %s | %.1f%% | %.1f%% | %s | %s | %s |\n",
+			name, c.MarketShare, c.GrowthRate, c.PriceComparison, c.Strengths, c.Weaknesses)
+	}
+
+	fmt.Fprintln(w, "\n## Category Performance")
+	fmt.Fprintln(w, "| Category | Market Size | Our Share | Trend | Sentiment |")
+	fmt.Fprintln(w, "|---|---|---|---|---|")
+	for _, name := range categoryNames(mr.Categories) {
+		c := mr.Categories[name]
+		fmt.Fprintf(w, "| %s | $%dM | %.1f%% | %s | %s |\n",
+			name, c.MarketSize/1000000, c.MarketShare, c.YearlyTrend, c.ConsumerSentiment)
+	}
+
+	fmt.Fprintln(w, "\n## Strategic Recommendations")
+	for i, rec := range mr.Recommendations {
+		fmt.Fprintf(w, "%d. %s\n", i+1, rec)
+	}
+
+	return nil
+}
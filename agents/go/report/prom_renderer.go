@@ -0,0 +1,43 @@
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+// PromRenderer renders a MarketReport as Prometheus text-exposition
+// format metrics, suitable for scraping.
+type PromRenderer struct{}
+
+// ContentType identifies the rendered payload per the Prometheus text
+// exposition format.
+func (PromRenderer) ContentType() string { return "text/plain; version=0.0.4" }
+
+// Render writes mr to w as Prometheus gauges.
+func (PromRenderer) Render(mr MarketReport, w io.Writer) error {
+	fmt.Fprintln(w, "# HELP market_size_dollars Total addressable market size in dollars.")
+	fmt.Fprintln(w, "# TYPE market_size_dollars gauge")
+	fmt.Fprintf(w, "market_size_dollars %d\n", mr.MarketSize)
+
+	fmt.Fprintln(w, "# HELP market_growth_percent Year-over-year market growth rate.")
+	fmt.Fprintln(w, "# TYPE market_growth_percent gauge")
+	fmt.Fprintf(w, "market_growth_percent %.2f\n", mr.MarketGrowth)
+
+	fmt.Fprintln(w, "# HELP our_market_share_percent Our share of the total market.")
+	fmt.Fprintln(w, "# TYPE our_market_share_percent gauge")
+	fmt.Fprintf(w, "our_market_share_percent %.2f\n", mr.OurMarketShare)
+
+	fmt.Fprintln(w, "# HELP competitor_market_share_percent Market share by competitor.")
+	fmt.Fprintln(w, "# TYPE competitor_market_share_percent gauge")
+	for _, name := range competitorNames(mr.Competitors) {
+		fmt.Fprintf(w, "competitor_market_share_percent{name=%q} %.2f\n", name, mr.Competitors[name].MarketShare)
+	}
+
+	fmt.Fprintln(w, "# HELP category_market_share_percent Our market share by category.")
+	fmt.Fprintln(w, "# TYPE category_market_share_percent gauge")
+	for _, name := range categoryNames(mr.Categories) {
+		fmt.Fprintf(w, "category_market_share_percent{category=%q} %.2f\n", name, mr.Categories[name].MarketShare)
+	}
+
+	return nil
+}
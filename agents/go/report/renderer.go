@@ -0,0 +1,11 @@
+package report
+
+import "io"
+
+// Renderer formats a MarketReport for a particular output medium.
+// ContentType is attached as a header on the published message so
+// subscribers know how to parse the payload without guessing.
+type Renderer interface {
+	ContentType() string
+	Render(MarketReport, io.Writer) error
+}
@@ -0,0 +1,128 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testReport is a fixed MarketReport used to smoke-test every Renderer
+// against the same, easy-to-assert-on input.
+func testReport() MarketReport {
+	return MarketReport{
+		Date:           "2024-01-01",
+		MarketSize:     9000000,
+		MarketGrowth:   5.5,
+		OurMarketShare: 18.2,
+		Competitors: map[string]CompetitorData{
+			"MegaRetail": {MarketShare: 20.1, GrowthRate: 4.2, PriceComparison: "10% higher", Strengths: "Brand", Weaknesses: "Price"},
+		},
+		Categories: map[string]CategoryAnalysis{
+			"Electronics": {MarketSize: 1200000, MarketShare: 15.5, YearlyTrend: "Stable", ConsumerSentiment: "Positive"},
+		},
+		Recommendations: []string{"Expand digital channels"},
+	}
+}
+
+func TestJSONRendererProducesValidJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (JSONRenderer{}).Render(testReport(), &buf); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	var decoded MarketReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("rendered output is not valid JSON: %v", err)
+	}
+	if decoded.Date != "2024-01-01" || decoded.MarketSize != 9000000 {
+		t.Errorf("decoded report lost data: %+v", decoded)
+	}
+}
+
+func TestMarkdownRendererProducesTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (MarkdownRenderer{}).Render(testReport(), &buf); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"# Market Analysis Report: 2024-01-01",
+		"| Competitor | Market Share | Growth Rate | Pricing | Strengths | Weaknesses |",
+		"| MegaRetail | 20.1% | 4.2% | 10% higher | Brand | Price |",
+		"| Electronics | $1M | 15.5% | Stable | Positive |",
+		"1. Expand digital channels",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\nfull output:\n%s", want, out)
+		}
+	}
+}
+
+func TestHTMLRendererExecutesTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (HTMLRenderer{}).Render(testReport(), &buf); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"<title>Market Analysis Report: 2024-01-01</title>",
+		"<td>MegaRetail</td>",
+		"Total Market Size: $9 million",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\nfull output:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "<table") != strings.Count(out, "</table>") {
+		t.Errorf("unbalanced <table> tags in output:\n%s", out)
+	}
+}
+
+var promMetricLine = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*(\{[^}]*\})? \S+$`)
+
+func TestPromRendererProducesParseableExposition(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (PromRenderer{}).Render(testReport(), &buf); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	sawMarketSize := false
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		if line == "" || strings.HasPrefix(line, "# HELP") || strings.HasPrefix(line, "# TYPE") {
+			continue
+		}
+		if !promMetricLine.MatchString(line) {
+			t.Errorf("line does not match Prometheus exposition format: %q", line)
+		}
+		if strings.HasPrefix(line, "market_size_dollars ") {
+			sawMarketSize = true
+		}
+	}
+	if !sawMarketSize {
+		t.Error("output never emitted market_size_dollars")
+	}
+}
+
+func TestTextRendererUsesInjectedClock(t *testing.T) {
+	var buf bytes.Buffer
+	r := TextRenderer{Now: func() time.Time { return time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC) }}
+	if err := r.Render(testReport(), &buf); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"MARKET ANALYSIS REPORT: 2024-01-01",
+		"MegaRetail",
+		"Generated at 09:30:00",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\nfull output:\n%s", want, out)
+		}
+	}
+}
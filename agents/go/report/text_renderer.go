@@ -0,0 +1,62 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// TextRenderer renders a MarketReport in the original ASCII-with-emoji
+// style, fixed to emit correct UTF-8 glyphs.
+type TextRenderer struct {
+	// Now stamps the report's footer. Defaults to time.Now if nil.
+	Now func() time.Time
+}
+
+// ContentType identifies the rendered payload as plain text.
+func (TextRenderer) ContentType() string { return "text/plain; charset=utf-8" }
+
+// Render writes mr to w in the emoji-annotated text layout.
+func (r TextRenderer) Render(mr MarketReport, w io.Writer) error {
+	now := r.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	fmt.Fprintf(w, "\n🌐 MARKET ANALYSIS REPORT: %s\n", mr.Date)
+	fmt.Fprintf(w, "📈 Total Market Size: $%d million\n", mr.MarketSize/1000000)
+	fmt.Fprintf(w, "📊 Market Growth Rate: %.1f%%\n", mr.MarketGrowth)
+	fmt.Fprintf(w, "🏢 Our Market Share: %.1f%%\n\n", mr.OurMarketShare)
+
+	fmt.Fprintln(w, "COMPETITOR ANALYSIS:")
+	fmt.Fprintln(w, "====================")
+	for _, name := range competitorNames(mr.Competitors) {
+		c := mr.Competitors[name]
+		fmt.Fprintf(w, "🏆 %s\n", name)
+		fmt.Fprintf(w, "   Market Share: %.1f%%\n", c.MarketShare)
+		fmt.Fprintf(w, "   Growth Rate: %.1f%%\n", c.GrowthRate)
+		fmt.Fprintf(w, "   Pricing: %s\n", c.PriceComparison)
+		fmt.Fprintf(w, "   Strengths: %s\n", c.Strengths)
+		fmt.Fprintf(w, "   Weaknesses: %s\n\n", c.Weaknesses)
+	}
+
+	fmt.Fprintln(w, "CATEGORY PERFORMANCE:")
+	fmt.Fprintln(w, "=====================")
+	for _, name := range categoryNames(mr.Categories) {
+		c := mr.Categories[name]
+		fmt.Fprintf(w, "📦 %s\n", name)
+		fmt.Fprintf(w, "   Market Size: $%d million\n", c.MarketSize/1000000)
+		fmt.Fprintf(w, "   Our Market Share: %.1f%%\n", c.MarketShare)
+		fmt.Fprintf(w, "   Yearly Trend: %s\n", c.YearlyTrend)
+		fmt.Fprintf(w, "   Consumer Sentiment: %s\n\n", c.ConsumerSentiment)
+	}
+
+	fmt.Fprintln(w, "STRATEGIC RECOMMENDATIONS:")
+	fmt.Fprintln(w, "=========================")
+	for i, rec := range mr.Recommendations {
+		fmt.Fprintf(w, "%d. %s\n", i+1, rec)
+	}
+
+	fmt.Fprintf(w, "\nGenerated at %s", now().Format("15:04:05"))
+	return nil
+}